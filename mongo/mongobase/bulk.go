@@ -0,0 +1,276 @@
+package mongobase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Server-side limits a single bulk write request must stay under; inputs
+// larger than this are automatically split into multiple requests.
+const (
+	maxBulkOps   = 100_000
+	maxBulkBytes = 16 * 1024 * 1024
+)
+
+// BulkOptions configures BulkWrite and InsertMany.
+type BulkOptions struct {
+	// Ordered, when true, stops execution at the first write error. When
+	// false, the server keeps executing the remaining operations and every
+	// error is collected in BulkResult.WriteErrors. The zero value is false
+	// (unordered) and is always sent explicitly, so it does not follow the
+	// driver's own default of true.
+	Ordered bool
+	// BypassDocumentValidation skips schema validation rules configured on
+	// the collection.
+	BypassDocumentValidation bool
+	// BatchSize caps how many operations are sent to the server per
+	// request. The input is always split to stay within the server's
+	// 100,000-operation / 16MiB limits regardless of BatchSize; set it
+	// lower to force smaller requests. Zero uses the server maximums.
+	BatchSize int
+}
+
+// BulkResult aggregates the outcome of a BulkWrite, InsertMany, UpdateMany
+// or DeleteMany call across however many batches the input was split into.
+type BulkResult struct {
+	InsertedCount int64
+	MatchedCount  int64
+	ModifiedCount int64
+	DeletedCount  int64
+	UpsertedCount int64
+	// UpsertedIDs maps the index of the operation in the slice passed to
+	// BulkWrite/InsertMany to the _id of the document it upserted.
+	UpsertedIDs map[int64]any
+	// WriteErrors collects every write error across all batches, with
+	// Index adjusted to refer back into the original input slice.
+	WriteErrors []mongo.BulkWriteError
+}
+
+// BulkOpKind identifies which operation a BulkOp represents.
+type BulkOpKind int
+
+const (
+	BulkInsert BulkOpKind = iota
+	BulkUpdateOne
+	BulkUpdateMany
+	BulkDeleteOne
+	BulkDeleteMany
+	BulkReplaceOne
+)
+
+// BulkOp is a single operation within a BulkWrite call. Build one with the
+// Bulk*Op constructor matching the operation you need rather than setting
+// fields directly.
+type BulkOp[T any] struct {
+	Kind        BulkOpKind
+	Document    T
+	Filter      bson.M
+	Update      bson.M
+	Replacement T
+	Upsert      bool
+}
+
+// BulkInsertOp inserts document.
+func BulkInsertOp[T any](document T) BulkOp[T] {
+	return BulkOp[T]{Kind: BulkInsert, Document: document}
+}
+
+// BulkUpdateOneOp updates at most one document matching filter.
+func BulkUpdateOneOp[T any](filter, update bson.M, upsert bool) BulkOp[T] {
+	return BulkOp[T]{Kind: BulkUpdateOne, Filter: filter, Update: update, Upsert: upsert}
+}
+
+// BulkUpdateManyOp updates every document matching filter.
+func BulkUpdateManyOp[T any](filter, update bson.M, upsert bool) BulkOp[T] {
+	return BulkOp[T]{Kind: BulkUpdateMany, Filter: filter, Update: update, Upsert: upsert}
+}
+
+// BulkDeleteOneOp deletes at most one document matching filter.
+func BulkDeleteOneOp[T any](filter bson.M) BulkOp[T] {
+	return BulkOp[T]{Kind: BulkDeleteOne, Filter: filter}
+}
+
+// BulkDeleteManyOp deletes every document matching filter.
+func BulkDeleteManyOp[T any](filter bson.M) BulkOp[T] {
+	return BulkOp[T]{Kind: BulkDeleteMany, Filter: filter}
+}
+
+// BulkReplaceOneOp replaces at most one document matching filter with
+// replacement.
+func BulkReplaceOneOp[T any](filter bson.M, replacement T, upsert bool) BulkOp[T] {
+	return BulkOp[T]{Kind: BulkReplaceOne, Filter: filter, Replacement: replacement, Upsert: upsert}
+}
+
+// toWriteModel converts op to the mongo.WriteModel BulkWrite sends to the
+// server, and returns an approximate wire size used to split batches.
+func (op BulkOp[T]) toWriteModel() (mongo.WriteModel, int, error) {
+	switch op.Kind {
+	case BulkInsert:
+		size, err := bsonSize(op.Document)
+		return mongo.NewInsertOneModel().SetDocument(op.Document), size, err
+	case BulkUpdateOne:
+		size, err := bsonSize(bson.M{"filter": op.Filter, "update": op.Update})
+		model := mongo.NewUpdateOneModel().SetFilter(op.Filter).SetUpdate(op.Update).SetUpsert(op.Upsert)
+		return model, size, err
+	case BulkUpdateMany:
+		size, err := bsonSize(bson.M{"filter": op.Filter, "update": op.Update})
+		model := mongo.NewUpdateManyModel().SetFilter(op.Filter).SetUpdate(op.Update).SetUpsert(op.Upsert)
+		return model, size, err
+	case BulkDeleteOne:
+		size, err := bsonSize(op.Filter)
+		return mongo.NewDeleteOneModel().SetFilter(op.Filter), size, err
+	case BulkDeleteMany:
+		size, err := bsonSize(op.Filter)
+		return mongo.NewDeleteManyModel().SetFilter(op.Filter), size, err
+	case BulkReplaceOne:
+		size, err := bsonSize(bson.M{"filter": op.Filter, "replacement": op.Replacement})
+		model := mongo.NewReplaceOneModel().SetFilter(op.Filter).SetReplacement(op.Replacement).SetUpsert(op.Upsert)
+		return model, size, err
+	default:
+		return nil, 0, fmt.Errorf("mongobase: unknown BulkOpKind %d", op.Kind)
+	}
+}
+
+func bsonSize(v any) (int, error) {
+	data, err := bson.Marshal(v)
+	return len(data), err
+}
+
+// BulkWrite executes ops against the collection in one or more batches,
+// automatically splitting the input to stay under the server's
+// 100,000-operation / 16MiB per-request limits, and aggregates the results
+// of every batch into a single BulkResult. Execution stops at the first
+// failing batch when opts.Ordered is true; otherwise every batch runs and
+// their errors are merged into BulkResult.WriteErrors.
+func (repo *MongoBaseRepository[T]) BulkWrite(ctx context.Context, ops []BulkOp[T], opts BulkOptions) (BulkResult, error) {
+	if len(ops) == 0 {
+		return BulkResult{}, nil
+	}
+
+	models := make([]mongo.WriteModel, len(ops))
+	sizes := make([]int, len(ops))
+	for i, op := range ops {
+		model, size, err := op.toWriteModel()
+		if err != nil {
+			return BulkResult{}, err
+		}
+		models[i] = model
+		sizes[i] = size
+	}
+
+	return repo.bulkWriteModels(ctx, models, sizes, opts)
+}
+
+// bulkWriteModels splits models into batches bounded by maxBulkOps,
+// maxBulkBytes and opts.BatchSize, runs each through the driver's BulkWrite,
+// and merges the per-batch results/errors into one BulkResult with indices
+// translated back to the original, unbatched slice.
+func (repo *MongoBaseRepository[T]) bulkWriteModels(ctx context.Context, models []mongo.WriteModel, sizes []int, opts BulkOptions) (BulkResult, error) {
+	maxOps := maxBulkOps
+	if opts.BatchSize > 0 && opts.BatchSize < maxOps {
+		maxOps = opts.BatchSize
+	}
+
+	bulkOpts := options.BulkWrite().SetOrdered(opts.Ordered).SetBypassDocumentValidation(opts.BypassDocumentValidation)
+
+	var result BulkResult
+	var firstErr error
+
+	for start := 0; start < len(models); {
+		end := start + 1
+		batchBytes := sizes[start]
+		for end < len(models) && end-start < maxOps {
+			batchBytes += sizes[end]
+			if batchBytes > maxBulkBytes {
+				break
+			}
+			end++
+		}
+
+		batchResult, err := repo.collection.BulkWrite(ctx, models[start:end], bulkOpts)
+		mergeBulkResult(&result, batchResult, start)
+
+		if err != nil {
+			var bulkErr mongo.BulkWriteException
+			if errors.As(err, &bulkErr) {
+				for _, we := range bulkErr.WriteErrors {
+					we.Index += start
+					result.WriteErrors = append(result.WriteErrors, we)
+				}
+			} else if firstErr == nil {
+				firstErr = err
+			}
+
+			if opts.Ordered {
+				return result, err
+			}
+		}
+
+		start = end
+	}
+
+	if firstErr != nil {
+		return result, firstErr
+	}
+	if len(result.WriteErrors) > 0 {
+		return result, mongo.BulkWriteException{WriteErrors: result.WriteErrors}
+	}
+
+	return result, nil
+}
+
+// mergeBulkResult folds a single batch's result into the aggregate,
+// translating UpsertedIDs keys by the batch's starting offset.
+func mergeBulkResult(result *BulkResult, batch *mongo.BulkWriteResult, offset int) {
+	if batch == nil {
+		return
+	}
+
+	result.InsertedCount += batch.InsertedCount
+	result.MatchedCount += batch.MatchedCount
+	result.ModifiedCount += batch.ModifiedCount
+	result.DeletedCount += batch.DeletedCount
+	result.UpsertedCount += batch.UpsertedCount
+
+	if len(batch.UpsertedIDs) == 0 {
+		return
+	}
+	if result.UpsertedIDs == nil {
+		result.UpsertedIDs = make(map[int64]any, len(batch.UpsertedIDs))
+	}
+	for index, id := range batch.UpsertedIDs {
+		result.UpsertedIDs[index+int64(offset)] = id
+	}
+}
+
+// InsertMany inserts docs, splitting them into batches per opts and
+// aggregating the results into a single BulkResult.
+func (repo *MongoBaseRepository[T]) InsertMany(ctx context.Context, docs []T, opts BulkOptions) (BulkResult, error) {
+	ops := make([]BulkOp[T], len(docs))
+	for i, doc := range docs {
+		ops[i] = BulkInsertOp(doc)
+	}
+	return repo.BulkWrite(ctx, ops, opts)
+}
+
+// UpdateMany applies update to every document matching filter.
+func (repo *MongoBaseRepository[T]) UpdateMany(ctx context.Context, filter, update bson.M) (BulkResult, error) {
+	return repo.BulkWrite(ctx, []BulkOp[T]{BulkUpdateManyOp[T](filter, update, false)}, BulkOptions{Ordered: true})
+}
+
+// DeleteMany deletes every document matching filter.
+func (repo *MongoBaseRepository[T]) DeleteMany(ctx context.Context, filter bson.M) (BulkResult, error) {
+	return repo.BulkWrite(ctx, []BulkOp[T]{BulkDeleteManyOp[T](filter)}, BulkOptions{Ordered: true})
+}
+
+// UpsertOneById replaces the document with the given _id with doc, inserting
+// it if it doesn't already exist.
+func (repo *MongoBaseRepository[T]) UpsertOneById(ctx context.Context, id string, doc T) error {
+	_, err := repo.collection.ReplaceOne(ctx, bson.M{"_id": id}, doc, options.Replace().SetUpsert(true))
+	return err
+}
@@ -0,0 +1,112 @@
+package mongobase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestMongoBaseRepository_InsertMany(t *testing.T) {
+	client, deployment := setupMockClient(t)
+	defer client.Disconnect(context.Background())
+
+	deployment.AddResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 2}})
+
+	repo := New[TestEntity](client, "testdb", "testcol")
+
+	result, err := repo.InsertMany(context.Background(), []TestEntity{
+		{ID: "test-id-1", Name: "John Doe", Age: 30},
+		{ID: "test-id-2", Name: "Jane Doe", Age: 25},
+	}, BulkOptions{Ordered: true})
+
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, result.InsertedCount)
+}
+
+func TestMongoBaseRepository_InsertMany_Empty(t *testing.T) {
+	client, _ := setupMockClient(t)
+	defer client.Disconnect(context.Background())
+
+	repo := New[TestEntity](client, "testdb", "testcol")
+
+	result, err := repo.InsertMany(context.Background(), nil, BulkOptions{})
+
+	require.NoError(t, err)
+	assert.Zero(t, result.InsertedCount)
+}
+
+func TestMongoBaseRepository_UpdateMany(t *testing.T) {
+	client, deployment := setupMockClient(t)
+	defer client.Disconnect(context.Background())
+
+	deployment.AddResponses(bson.D{
+		{Key: "ok", Value: 1},
+		{Key: "n", Value: 2},
+		{Key: "nModified", Value: 2},
+	})
+
+	repo := New[TestEntity](client, "testdb", "testcol")
+
+	result, err := repo.UpdateMany(context.Background(), bson.M{"age": bson.M{"$gte": 25}}, bson.M{"$set": bson.M{"age": 40}})
+
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, result.MatchedCount)
+	assert.EqualValues(t, 2, result.ModifiedCount)
+}
+
+func TestMongoBaseRepository_DeleteMany(t *testing.T) {
+	client, deployment := setupMockClient(t)
+	defer client.Disconnect(context.Background())
+
+	deployment.AddResponses(bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 3}})
+
+	repo := New[TestEntity](client, "testdb", "testcol")
+
+	result, err := repo.DeleteMany(context.Background(), bson.M{"age": bson.M{"$lt": 18}})
+
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, result.DeletedCount)
+}
+
+func TestMongoBaseRepository_UpsertOneById(t *testing.T) {
+	client, deployment := setupMockClient(t)
+	defer client.Disconnect(context.Background())
+
+	deployment.AddResponses(bson.D{
+		{Key: "ok", Value: 1},
+		{Key: "n", Value: 1},
+		{Key: "upserted", Value: bson.A{bson.D{{Key: "index", Value: 0}, {Key: "_id", Value: "test-id-1"}}}},
+	})
+
+	repo := New[TestEntity](client, "testdb", "testcol")
+
+	err := repo.UpsertOneById(context.Background(), "test-id-1", TestEntity{ID: "test-id-1", Name: "John Doe", Age: 30})
+
+	require.NoError(t, err)
+}
+
+func TestSplitIntoBatches_RespectsBatchSize(t *testing.T) {
+	client, deployment := setupMockClient(t)
+	defer client.Disconnect(context.Background())
+
+	deployment.AddResponses(
+		bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 2}},
+		bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: 1}},
+	)
+
+	repo := New[TestEntity](client, "testdb", "testcol")
+
+	ops := []BulkOp[TestEntity]{
+		BulkInsertOp(TestEntity{ID: "1"}),
+		BulkInsertOp(TestEntity{ID: "2"}),
+		BulkInsertOp(TestEntity{ID: "3"}),
+	}
+
+	result, err := repo.BulkWrite(context.Background(), ops, BulkOptions{Ordered: true, BatchSize: 2})
+
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, result.InsertedCount)
+}
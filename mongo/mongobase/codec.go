@@ -0,0 +1,133 @@
+package mongobase
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// EncodeFunc and DecodeFunc register a custom BSON representation for a
+// type via RegisterCodec. They're aliases for the driver's own codec
+// function types, so existing bson.ValueEncoderFunc/ValueDecoderFunc values
+// can be passed directly.
+type EncodeFunc = bson.ValueEncoderFunc
+type DecodeFunc = bson.ValueDecoderFunc
+
+// RegisterCodec registers encode/decode functions for t on registry, so
+// every collection built with WithRegistry(registry) uses them to
+// marshal/unmarshal fields of that type.
+func RegisterCodec(registry *bson.Registry, t reflect.Type, encode EncodeFunc, decode DecodeFunc) {
+	registry.RegisterTypeEncoder(t, encode)
+	registry.RegisterTypeDecoder(t, decode)
+}
+
+// RegisterBuiltinCodecs registers the uuid.UUID, decimal.Decimal and
+// millisecond-truncated time.Time codecs below on registry. Call it when
+// building a registry for WithRegistry if the entity uses any of those
+// types.
+func RegisterBuiltinCodecs(registry *bson.Registry) {
+	RegisterCodec(registry, reflect.TypeOf(uuid.UUID{}), EncodeUUID, DecodeUUID)
+	RegisterCodec(registry, reflect.TypeOf(decimal.Decimal{}), EncodeDecimal, DecodeDecimal)
+	RegisterCodec(registry, reflect.TypeOf(time.Time{}), EncodeTimeMillis, DecodeTimeMillis)
+}
+
+// uuidSubtype is the BSON binary subtype conventionally used for UUIDs.
+const uuidSubtype = 0x04
+
+// EncodeUUID writes a uuid.UUID as a BSON binary value with subtype 0x04.
+func EncodeUUID(ec bson.EncodeContext, vw bson.ValueWriter, val reflect.Value) error {
+	if val.Type() != reflect.TypeOf(uuid.UUID{}) {
+		return fmt.Errorf("mongobase: EncodeUUID got invalid type %s", val.Type())
+	}
+	id := val.Interface().(uuid.UUID)
+	return vw.WriteBinaryWithSubtype(id[:], uuidSubtype)
+}
+
+// DecodeUUID reads a BSON binary value with subtype 0x04 into a uuid.UUID.
+func DecodeUUID(dc bson.DecodeContext, vr bson.ValueReader, val reflect.Value) error {
+	if val.Type() != reflect.TypeOf(uuid.UUID{}) {
+		return fmt.Errorf("mongobase: DecodeUUID got invalid type %s", val.Type())
+	}
+
+	data, subtype, err := vr.ReadBinary()
+	if err != nil {
+		return err
+	}
+	if subtype != uuidSubtype {
+		return fmt.Errorf("mongobase: DecodeUUID got invalid binary subtype %x", subtype)
+	}
+
+	id, err := uuid.FromBytes(data)
+	if err != nil {
+		return err
+	}
+
+	val.Set(reflect.ValueOf(id))
+	return nil
+}
+
+// EncodeDecimal writes a decimal.Decimal as a BSON Decimal128 value.
+func EncodeDecimal(ec bson.EncodeContext, vw bson.ValueWriter, val reflect.Value) error {
+	if val.Type() != reflect.TypeOf(decimal.Decimal{}) {
+		return fmt.Errorf("mongobase: EncodeDecimal got invalid type %s", val.Type())
+	}
+	d := val.Interface().(decimal.Decimal)
+
+	d128, ok := bson.ParseDecimal128FromBigInt(d.Coefficient(), int(d.Exponent()))
+	if !ok {
+		return fmt.Errorf("mongobase: decimal %s cannot be represented as Decimal128", d)
+	}
+
+	return vw.WriteDecimal128(d128)
+}
+
+// DecodeDecimal reads a BSON Decimal128 value into a decimal.Decimal.
+func DecodeDecimal(dc bson.DecodeContext, vr bson.ValueReader, val reflect.Value) error {
+	if val.Type() != reflect.TypeOf(decimal.Decimal{}) {
+		return fmt.Errorf("mongobase: DecodeDecimal got invalid type %s", val.Type())
+	}
+
+	d128, err := vr.ReadDecimal128()
+	if err != nil {
+		return err
+	}
+
+	coefficient, exponent, err := d128.BigInt()
+	if err != nil {
+		return err
+	}
+
+	val.Set(reflect.ValueOf(decimal.NewFromBigInt(coefficient, int32(exponent))))
+	return nil
+}
+
+// EncodeTimeMillis writes a time.Time truncated to millisecond precision,
+// MongoDB's native Date precision. Sub-millisecond precision of the original
+// Go value is dropped, so a decoded value equals the original only at
+// millisecond granularity.
+func EncodeTimeMillis(ec bson.EncodeContext, vw bson.ValueWriter, val reflect.Value) error {
+	if val.Type() != reflect.TypeOf(time.Time{}) {
+		return fmt.Errorf("mongobase: EncodeTimeMillis got invalid type %s", val.Type())
+	}
+	t := val.Interface().(time.Time)
+	return vw.WriteDateTime(t.UnixMilli())
+}
+
+// DecodeTimeMillis reads a BSON Date value into a time.Time in UTC.
+func DecodeTimeMillis(dc bson.DecodeContext, vr bson.ValueReader, val reflect.Value) error {
+	if val.Type() != reflect.TypeOf(time.Time{}) {
+		return fmt.Errorf("mongobase: DecodeTimeMillis got invalid type %s", val.Type())
+	}
+
+	millis, err := vr.ReadDateTime()
+	if err != nil {
+		return err
+	}
+
+	val.Set(reflect.ValueOf(time.UnixMilli(millis).UTC()))
+	return nil
+}
@@ -0,0 +1,108 @@
+package mongobase
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type codecTestEntity struct {
+	ID     string          `bson:"_id,omitempty"`
+	UUID   uuid.UUID       `bson:"uuid"`
+	Amount decimal.Decimal `bson:"amount"`
+	AsOf   time.Time       `bson:"asOf"`
+}
+
+// marshalWithRegistry/unmarshalWithRegistry round-trip val through a buffer
+// using registry, the way the driver does internally for a collection built
+// with WithRegistry.
+func marshalWithRegistry(t *testing.T, registry *bson.Registry, val any) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	enc := bson.NewEncoder(bson.NewDocumentWriter(&buf))
+	enc.SetRegistry(registry)
+	require.NoError(t, enc.Encode(val))
+
+	return buf.Bytes()
+}
+
+func unmarshalWithRegistry(t *testing.T, registry *bson.Registry, data []byte, val any) {
+	t.Helper()
+
+	dec := bson.NewDecoder(bson.NewDocumentReader(bytes.NewReader(data)))
+	dec.SetRegistry(registry)
+	require.NoError(t, dec.Decode(val))
+}
+
+func TestRegisterBuiltinCodecs_RoundTrip(t *testing.T) {
+	registry := bson.NewRegistry()
+	RegisterBuiltinCodecs(registry)
+
+	original := codecTestEntity{
+		ID:     "entity-1",
+		UUID:   uuid.New(),
+		Amount: decimal.RequireFromString("12.3456"),
+		AsOf:   time.Date(2026, 7, 27, 12, 0, 0, 123_000_000, time.UTC),
+	}
+
+	data := marshalWithRegistry(t, registry, original)
+
+	var decoded codecTestEntity
+	unmarshalWithRegistry(t, registry, data, &decoded)
+
+	assert.Equal(t, original.ID, decoded.ID)
+	assert.Equal(t, original.UUID, decoded.UUID)
+	assert.True(t, original.Amount.Equal(decoded.Amount))
+	assert.True(t, original.AsOf.Equal(decoded.AsOf))
+}
+
+func TestEncodeTimeMillis_TruncatesSubMillisecondPrecision(t *testing.T) {
+	registry := bson.NewRegistry()
+	RegisterBuiltinCodecs(registry)
+
+	type withTime struct {
+		AsOf time.Time `bson:"asOf"`
+	}
+
+	original := withTime{AsOf: time.Date(2026, 7, 27, 12, 0, 0, 123_456_789, time.UTC)}
+
+	data := marshalWithRegistry(t, registry, original)
+
+	var decoded withTime
+	unmarshalWithRegistry(t, registry, data, &decoded)
+
+	assert.Equal(t, original.AsOf.UnixMilli(), decoded.AsOf.UnixMilli())
+}
+
+func TestDecodeUUID_RejectsWrongSubtype(t *testing.T) {
+	registry := bson.NewRegistry()
+	RegisterBuiltinCodecs(registry)
+
+	type withUUID struct {
+		UUID uuid.UUID `bson:"uuid"`
+	}
+
+	var buf bytes.Buffer
+	dw, err := bson.NewDocumentWriter(&buf).WriteDocument()
+	require.NoError(t, err)
+	vw, err := dw.WriteDocumentElement("uuid")
+	require.NoError(t, err)
+	require.NoError(t, vw.WriteBinaryWithSubtype([]byte("not-a-uuid-subtype"), 0x00))
+	require.NoError(t, dw.WriteDocumentEnd())
+
+	var decoded withUUID
+	unmarshalErr := func() error {
+		dec := bson.NewDecoder(bson.NewDocumentReader(bytes.NewReader(buf.Bytes())))
+		dec.SetRegistry(registry)
+		return dec.Decode(&decoded)
+	}()
+
+	assert.Error(t, unmarshalErr)
+}
@@ -1,5 +1,7 @@
 package mongobase
 
+import "time"
+
 // This struct is only used to demonstrate the usage of the MongoBaseRepository,
 // and is not intended to be used directly in the application.
 //
@@ -7,3 +9,16 @@ package mongobase
 type Entity struct {
 	ID string `bson:"_id,omitempty"`
 }
+
+// Timestamps can be embedded into an entity to get createdAt/updatedAt
+// tracking and to have EnsureIndexes auto-inject indexes on those fields.
+//
+//	type User struct {
+//		Entity
+//		mongobase.Timestamps
+//		Name string `bson:"name"`
+//	}
+type Timestamps struct {
+	CreatedAt time.Time `bson:"createdAt"`
+	UpdatedAt time.Time `bson:"updatedAt"`
+}
@@ -2,9 +2,12 @@ package mongobase
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
 // This interface defines the list of methods currently supported by the MongoBaseRepository.
@@ -20,7 +23,10 @@ type IMongoBaseRepository interface {
 }
 
 type MongoBaseRepository[T any] struct {
-	collection *mongo.Collection
+	collection  *mongo.Collection
+	indexes     []mongo.IndexModel
+	registry    *bson.Registry
+	idGenerator func() string
 }
 
 // Creates a new instance of the MongoBaseRepository
@@ -47,11 +53,106 @@ type MongoBaseRepository[T any] struct {
 //
 //	func (UserRepository) CreateUserWithCustomLogic(ctx context.Context, user entity.User) error { ... }
 //	// ... other methods specific to UserRepository
-func New[T any](client *mongo.Client, databaseName, collectionName string) *MongoBaseRepository[T] {
-	collection := client.Database(databaseName).Collection(collectionName)
-	return &MongoBaseRepository[T]{
-		collection: collection,
+//
+// opts can include WithRegistry to customize BSON encoding/decoding and
+// WithIDGenerator to auto-populate _id on InsertOne.
+func New[T any](client *mongo.Client, databaseName, collectionName string, opts ...RepositoryOption[T]) *MongoBaseRepository[T] {
+	return NewWithIndexes[T](client, databaseName, collectionName, nil, opts...)
+}
+
+// Creates a new instance of the MongoBaseRepository with a set of indexes to
+// be ensured via EnsureIndexes.
+//
+// Parameters:
+//   - client: The MongoDB client to use
+//   - databaseName: The name of the database
+//   - collectionName: The name of the collection to operate on
+//   - indexes: The indexes that EnsureIndexes should create or verify
+//
+// Returns:
+//   - A pointer to the MongoBaseRepository instance
+//
+// Note:
+//   - If T embeds Timestamps, indexes on "createdAt" and "updatedAt" are
+//     appended automatically unless indexes already cover those keys.
+//   - opts can include WithRegistry to customize BSON encoding/decoding and
+//     WithIDGenerator to auto-populate _id on InsertOne.
+func NewWithIndexes[T any](client *mongo.Client, databaseName, collectionName string, indexes []mongo.IndexModel, opts ...RepositoryOption[T]) *MongoBaseRepository[T] {
+	repo := &MongoBaseRepository[T]{
+		indexes: withTimestampIndexes[T](indexes),
+	}
+	for _, opt := range opts {
+		opt(repo)
 	}
+
+	collOpts := options.Collection()
+	if repo.registry != nil {
+		collOpts.SetRegistry(repo.registry)
+	}
+	repo.collection = client.Database(databaseName).Collection(collectionName, collOpts)
+
+	return repo
+}
+
+// withTimestampIndexes appends indexes on "createdAt" and "updatedAt" when T
+// embeds Timestamps and the caller hasn't already declared an index on that key.
+func withTimestampIndexes[T any](indexes []mongo.IndexModel) []mongo.IndexModel {
+	if !embedsTimestamps[T]() {
+		return indexes
+	}
+
+	declared := make(map[string]bool, len(indexes))
+	for _, idx := range indexes {
+		if keys, ok := idx.Keys.(bson.D); ok {
+			for _, key := range keys {
+				declared[key.Key] = true
+			}
+		}
+	}
+
+	for _, field := range []string{"createdAt", "updatedAt"} {
+		if declared[field] {
+			continue
+		}
+		indexes = append(indexes, mongo.IndexModel{
+			Keys: bson.D{{Key: field, Value: 1}},
+		})
+	}
+
+	return indexes
+}
+
+// embedsTimestamps reports whether T embeds Timestamps, directly or through
+// an embedded struct, so EnsureIndexes can auto-inject the common indexes.
+func embedsTimestamps[T any]() bool {
+	t := reflect.TypeOf(*new(T))
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return false
+	}
+
+	return structEmbedsTimestamps(t)
+}
+
+// structEmbedsTimestamps searches t's anonymous struct fields recursively
+// for an embedded Timestamps.
+func structEmbedsTimestamps(t reflect.Type) bool {
+	timestampsType := reflect.TypeOf(Timestamps{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.Anonymous {
+			continue
+		}
+		if field.Type == timestampsType {
+			return true
+		}
+		if field.Type.Kind() == reflect.Struct && structEmbedsTimestamps(field.Type) {
+			return true
+		}
+	}
+	return false
 }
 
 // Inserts a single document into the collection
@@ -63,7 +164,15 @@ func New[T any](client *mongo.Client, databaseName, collectionName string) *Mong
 // Returns:
 //   - nil if the insertion is successful
 //   - An error if the insertion fails
+//
+// Note:
+//   - If an IDGenerator was configured via WithIDGenerator and document's
+//     _id field is empty, it's populated with a generated ID before insertion.
 func (repo *MongoBaseRepository[T]) InsertOne(ctx context.Context, document T) error {
+	if repo.idGenerator != nil {
+		setGeneratedID(&document, repo.idGenerator())
+	}
+
 	_, err := repo.collection.InsertOne(ctx, document)
 	if err != nil {
 		return err
@@ -161,3 +270,134 @@ func (repo *MongoBaseRepository[T]) DeleteOneById(ctx context.Context, id string
 
 	return nil
 }
+
+// Ensures the indexes configured via NewWithIndexes (plus any auto-injected
+// Timestamps indexes) exist on the collection.
+//
+// Parameters:
+//   - ctx: The context for the operation
+//   - create: When true, creates any missing indexes via CreateMany. When
+//     false, only verifies that every configured index already exists and
+//     reports drift instead of creating anything.
+//
+// Returns:
+//   - The names of the indexes that were created (create=true), or the key
+//     signatures (e.g. "name:1;") of the configured indexes that are already
+//     present (create=false)
+//   - An error if index creation fails, or if create is false and one or
+//     more configured indexes are missing (drift)
+//
+// The create=false drift check only compares index key shape (via
+// indexSignature/indexSignatureFromD); it does not compare index options
+// such as unique, expireAfterSeconds or partialFilterExpression. An index
+// whose keys match but whose options changed in code is reported as
+// present, even though it would need a collMod or drop-and-recreate to pick
+// up the new options.
+func (repo *MongoBaseRepository[T]) EnsureIndexes(ctx context.Context, create bool) ([]string, error) {
+	if len(repo.indexes) == 0 {
+		return nil, nil
+	}
+
+	if create {
+		return repo.collection.Indexes().CreateMany(ctx, repo.indexes)
+	}
+
+	specs, err := repo.collection.Indexes().ListSpecifications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		keys, err := bson.Raw(spec.KeysDocument).Elements()
+		if err != nil {
+			return nil, err
+		}
+		sig, err := indexSignature(keys)
+		if err != nil {
+			return nil, err
+		}
+		existing[sig] = true
+	}
+
+	names := make([]string, 0, len(repo.indexes))
+	var missing []string
+	for _, idx := range repo.indexes {
+		keys, ok := idx.Keys.(bson.D)
+		if !ok {
+			return nil, fmt.Errorf("mongobase: EnsureIndexes requires bson.D keys, got %T", idx.Keys)
+		}
+
+		sig, err := indexSignatureFromD(keys)
+		if err != nil {
+			return nil, err
+		}
+		if !existing[sig] {
+			missing = append(missing, sig)
+			continue
+		}
+		names = append(names, sig)
+	}
+
+	if len(missing) > 0 {
+		return names, fmt.Errorf("mongobase: index drift detected, missing indexes: %v", missing)
+	}
+
+	return names, nil
+}
+
+// indexSignature builds a comparable representation of an index's keys from
+// the raw elements returned by ListSpecifications, normalizing each value so
+// it compares equal to the same value declared as a Go literal (see
+// normalizeIndexValue).
+func indexSignature(elements []bson.RawElement) (string, error) {
+	sig := ""
+	for _, el := range elements {
+		var value any
+		if err := el.Value().Unmarshal(&value); err != nil {
+			return "", err
+		}
+		normalized, err := normalizeIndexValue(value)
+		if err != nil {
+			return "", err
+		}
+		sig += fmt.Sprintf("%s:%v;", el.Key(), normalized)
+	}
+	return sig, nil
+}
+
+// indexSignatureFromD builds the same representation as indexSignature but
+// from the bson.D keys an IndexModel was declared with.
+func indexSignatureFromD(keys bson.D) (string, error) {
+	sig := ""
+	for _, key := range keys {
+		normalized, err := normalizeIndexValue(key.Value)
+		if err != nil {
+			return "", err
+		}
+		sig += fmt.Sprintf("%s:%v;", key.Key, normalized)
+	}
+	return sig, nil
+}
+
+// normalizeIndexValue reduces an index key's value (e.g. 1, int32(1),
+// float64(1) for ascending/descending, or a string like "text"/"2dsphere"
+// for special index types) to a form that compares equal regardless of
+// which concrete numeric type it arrived as, so a value declared in Go as
+// `1` matches the same value decoded from a server response as `int32(1)`.
+func normalizeIndexValue(value any) (any, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		return int64(v), nil
+	case string:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("mongobase: unsupported index key value type %T", value)
+	}
+}
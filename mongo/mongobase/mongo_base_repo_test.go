@@ -319,6 +319,104 @@ func TestMongoBaseRepository_UpdateOneById(t *testing.T) {
 	}
 }
 
+type TestEntityWithTimestamps struct {
+	ID string `bson:"_id,omitempty"`
+	Timestamps
+	Name string `bson:"name"`
+}
+
+func TestNewWithIndexes_InjectsTimestampIndexes(t *testing.T) {
+	client, _ := setupMockClient(t, bson.D{{Key: "ok", Value: 1}})
+	defer client.Disconnect(context.Background())
+
+	repo := NewWithIndexes[TestEntityWithTimestamps](client, "testdb", "testcol", []mongo.IndexModel{
+		{Keys: bson.D{{Key: "name", Value: 1}}},
+	})
+
+	assert.Len(t, repo.indexes, 3)
+}
+
+func TestMongoBaseRepository_EnsureIndexes_Create(t *testing.T) {
+	client, deployment := setupMockClient(t)
+	defer client.Disconnect(context.Background())
+
+	deployment.AddResponses(bson.D{{Key: "ok", Value: 1}})
+
+	repo := NewWithIndexes[TestEntity](client, "testdb", "testcol", []mongo.IndexModel{
+		{Keys: bson.D{{Key: "name", Value: 1}}},
+	})
+
+	names, err := repo.EnsureIndexes(context.Background(), true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"name_1"}, names)
+}
+
+func TestMongoBaseRepository_EnsureIndexes_VerifyDrift(t *testing.T) {
+	client, deployment := setupMockClient(t)
+	defer client.Disconnect(context.Background())
+
+	deployment.AddResponses(bson.D{
+		{Key: "ok", Value: 1},
+		{Key: "cursor", Value: bson.D{
+			{Key: "id", Value: int64(0)},
+			{Key: "ns", Value: "testdb.testcol"},
+			{Key: "firstBatch", Value: bson.A{
+				bson.D{
+					{Key: "v", Value: int32(2)},
+					{Key: "key", Value: bson.D{{Key: "_id", Value: 1}}},
+					{Key: "name", Value: "_id_"},
+				},
+			}},
+		}},
+	})
+
+	repo := NewWithIndexes[TestEntity](client, "testdb", "testcol", []mongo.IndexModel{
+		{Keys: bson.D{{Key: "name", Value: 1}}},
+	})
+
+	names, err := repo.EnsureIndexes(context.Background(), false)
+
+	assert.Error(t, err)
+	assert.Empty(t, names)
+}
+
+func TestMongoBaseRepository_EnsureIndexes_VerifyNoDrift(t *testing.T) {
+	client, deployment := setupMockClient(t)
+	defer client.Disconnect(context.Background())
+
+	deployment.AddResponses(bson.D{
+		{Key: "ok", Value: 1},
+		{Key: "cursor", Value: bson.D{
+			{Key: "id", Value: int64(0)},
+			{Key: "ns", Value: "testdb.testcol"},
+			{Key: "firstBatch", Value: bson.A{
+				bson.D{
+					{Key: "v", Value: int32(2)},
+					{Key: "key", Value: bson.D{{Key: "_id", Value: 1}}},
+					{Key: "name", Value: "_id_"},
+				},
+				bson.D{
+					{Key: "v", Value: int32(2)},
+					// Index keys come back from a real server as int32, not
+					// as the plain Go int an IndexModel is declared with.
+					{Key: "key", Value: bson.D{{Key: "name", Value: int32(1)}}},
+					{Key: "name", Value: "name_1"},
+				},
+			}},
+		}},
+	})
+
+	repo := NewWithIndexes[TestEntity](client, "testdb", "testcol", []mongo.IndexModel{
+		{Keys: bson.D{{Key: "name", Value: 1}}},
+	})
+
+	names, err := repo.EnsureIndexes(context.Background(), false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"name:1;"}, names)
+}
+
 func TestMongoBaseRepository_DeleteOneById(t *testing.T) {
 	tests := []struct {
 		name          string
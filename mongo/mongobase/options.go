@@ -0,0 +1,88 @@
+package mongobase
+
+import (
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// RepositoryOption configures a MongoBaseRepository at construction time.
+// Pass one or more to New or NewWithIndexes.
+type RepositoryOption[T any] func(*MongoBaseRepository[T])
+
+// WithRegistry sets the BSON codec registry used to marshal and unmarshal T,
+// so custom codecs registered via RegisterCodec (or RegisterBuiltinCodecs)
+// apply to every operation on the repository.
+func WithRegistry[T any](registry *bson.Registry) RepositoryOption[T] {
+	return func(repo *MongoBaseRepository[T]) {
+		repo.registry = registry
+	}
+}
+
+// WithIDGenerator sets a function InsertOne uses to populate the document's
+// _id field when it's left as its zero value, so callers don't have to
+// pre-fill it themselves. See NewObjectIDGenerator for a ready-made
+// generator backed by ObjectID.
+func WithIDGenerator[T any](gen func() string) RepositoryOption[T] {
+	return func(repo *MongoBaseRepository[T]) {
+		repo.idGenerator = gen
+	}
+}
+
+// NewObjectIDGenerator returns an ID generator that produces hex-encoded
+// ObjectIDs, for use with WithIDGenerator.
+func NewObjectIDGenerator() func() string {
+	return func() string {
+		return bson.NewObjectID().Hex()
+	}
+}
+
+// setGeneratedID populates the bson-tagged "_id" field of document with id,
+// if that field exists, is a settable string, and is currently empty. The
+// field is commonly declared on an embedded Entity rather than directly on
+// T, so idField walks into anonymous struct fields to find it.
+func setGeneratedID(document any, id string) {
+	val := reflect.ValueOf(document).Elem()
+	field := idField(val)
+	if !field.IsValid() || field.Kind() != reflect.String || !field.CanSet() {
+		return
+	}
+	if field.String() != "" {
+		return
+	}
+	field.SetString(id)
+}
+
+// idField locates the struct field tagged bson:"_id" (ignoring any options
+// like omitempty), searching anonymous embedded fields recursively.
+func idField(val reflect.Value) reflect.Value {
+	t := val.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if tagKey(field.Tag.Get("bson")) == "_id" {
+			return val.Field(i)
+		}
+
+		if field.Anonymous {
+			embedded := val.Field(i)
+			if embedded.Kind() == reflect.Struct {
+				if found := idField(embedded); found.IsValid() {
+					return found
+				}
+			}
+		}
+	}
+	return reflect.Value{}
+}
+
+// tagKey returns the name portion of a bson struct tag, e.g. "_id" for
+// "_id,omitempty".
+func tagKey(tag string) string {
+	for i := 0; i < len(tag); i++ {
+		if tag[i] == ',' {
+			return tag[:i]
+		}
+	}
+	return tag
+}
@@ -0,0 +1,78 @@
+package mongobase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestWithIDGenerator_PopulatesEmptyID(t *testing.T) {
+	client, deployment := setupMockClient(t)
+	defer client.Disconnect(context.Background())
+
+	deployment.AddResponses(bson.D{
+		{Key: "ok", Value: 1},
+		{Key: "acknowledged", Value: true},
+		{Key: "insertedId", Value: "generated-id"},
+	})
+
+	repo := New[TestEntity](client, "testdb", "testcol", WithIDGenerator[TestEntity](func() string {
+		return "generated-id"
+	}))
+
+	document := TestEntity{Name: "Jane Doe", Age: 25}
+	err := repo.InsertOne(context.Background(), document)
+
+	require.NoError(t, err)
+}
+
+func TestWithIDGenerator_LeavesExistingIDAlone(t *testing.T) {
+	var generated bool
+
+	generator := func() string {
+		generated = true
+		return "should-not-be-used"
+	}
+
+	document := TestEntity{ID: "explicit-id", Name: "Jane Doe"}
+	setGeneratedID(&document, generator())
+
+	assert.Equal(t, "explicit-id", document.ID)
+	assert.True(t, generated, "generator is still called; it's setGeneratedID that must ignore its result")
+}
+
+func TestSetGeneratedID_FindsIDOnEmbeddedEntity(t *testing.T) {
+	type withEmbeddedEntity struct {
+		Entity
+		Name string `bson:"name"`
+	}
+
+	document := withEmbeddedEntity{Name: "Jane Doe"}
+	setGeneratedID(&document, "generated-id")
+
+	assert.Equal(t, "generated-id", document.ID)
+}
+
+func TestSetGeneratedID_NoOpWhenNoIDField(t *testing.T) {
+	type withoutID struct {
+		Name string `bson:"name"`
+	}
+
+	document := withoutID{Name: "Jane Doe"}
+	assert.NotPanics(t, func() {
+		setGeneratedID(&document, "generated-id")
+	})
+}
+
+func TestNewObjectIDGenerator_ProducesUniqueHexIDs(t *testing.T) {
+	gen := NewObjectIDGenerator()
+
+	first := gen()
+	second := gen()
+
+	assert.NotEmpty(t, first)
+	assert.NotEqual(t, first, second)
+}
@@ -0,0 +1,227 @@
+package mongobase
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// PageOptions configures FindPage.
+//
+// Either Skip or Cursor can be used to page through results; when both are
+// set, Cursor takes precedence since keyset pagination is stable under
+// concurrent writes while offset-based Skip is not.
+type PageOptions struct {
+	Limit        int64
+	Skip         int64
+	Cursor       string
+	Sort         bson.D
+	Projection   bson.M
+	IncludeTotal bool
+}
+
+// Page is the result of FindPage.
+type Page[T any] struct {
+	Items []T
+	// NextCursor is set when Limit was reached, meaning there may be more
+	// results. Pass it back as PageOptions.Cursor to fetch the next page.
+	NextCursor string
+	// Total is the number of documents matching the filter, populated only
+	// when PageOptions.IncludeTotal is set.
+	Total *int64
+}
+
+// FindPage finds documents matching filter, paged according to opts.
+//
+// In every mode the sort gets "_id" appended as a tiebreaker (unless it
+// already orders by "_id"). When opts.Cursor is empty, Sort plus Skip/Limit
+// behave like a regular Find. When opts.Cursor is set (or a previous
+// FindPage call with a Limit returned a NextCursor), pagination switches to
+// keyset mode: the cursor is translated into an $or range filter over the
+// sort fields so ordering stays stable even if documents are inserted or
+// removed between pages.
+func (repo *MongoBaseRepository[T]) FindPage(ctx context.Context, filter bson.M, opts PageOptions) (Page[T], error) {
+	sort := withIDTiebreaker(opts.Sort)
+
+	effectiveFilter := filter
+	if opts.Cursor != "" {
+		seekFilter, err := decodeCursorFilter(opts.Cursor, sort)
+		if err != nil {
+			return Page[T]{}, fmt.Errorf("mongobase: decoding cursor: %w", err)
+		}
+		effectiveFilter = mergeFilters(filter, seekFilter)
+	}
+
+	findOpts := options.Find().SetSort(sort)
+	if opts.Limit > 0 {
+		findOpts.SetLimit(opts.Limit)
+	}
+	if opts.Cursor == "" && opts.Skip > 0 {
+		findOpts.SetSkip(opts.Skip)
+	}
+	if opts.Projection != nil {
+		findOpts.SetProjection(opts.Projection)
+	}
+
+	cursor, err := repo.collection.Find(ctx, effectiveFilter, findOpts)
+	if err != nil {
+		return Page[T]{}, err
+	}
+	defer cursor.Close(ctx)
+
+	var items []T
+	for cursor.Next(ctx) {
+		var item T
+		if err := cursor.Decode(&item); err != nil {
+			return Page[T]{}, err
+		}
+		items = append(items, item)
+	}
+	if err := cursor.Err(); err != nil {
+		return Page[T]{}, err
+	}
+
+	page := Page[T]{Items: items}
+
+	if opts.Limit > 0 && int64(len(items)) == opts.Limit {
+		nextCursor, err := encodeCursor(items[len(items)-1], sort)
+		if err != nil {
+			return Page[T]{}, fmt.Errorf("mongobase: encoding next cursor: %w", err)
+		}
+		page.NextCursor = nextCursor
+	}
+
+	if opts.IncludeTotal {
+		total, err := repo.Count(ctx, filter)
+		if err != nil {
+			return Page[T]{}, err
+		}
+		page.Total = &total
+	}
+
+	return page, nil
+}
+
+// Count returns the number of documents matching filter.
+func (repo *MongoBaseRepository[T]) Count(ctx context.Context, filter bson.M) (int64, error) {
+	return repo.collection.CountDocuments(ctx, filter)
+}
+
+// Exists reports whether at least one document matches filter.
+func (repo *MongoBaseRepository[T]) Exists(ctx context.Context, filter bson.M) (bool, error) {
+	count, err := repo.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// withIDTiebreaker returns sort with an ascending "_id" field appended,
+// unless sort already orders by "_id".
+func withIDTiebreaker(sort bson.D) bson.D {
+	if len(sort) == 0 {
+		return bson.D{{Key: "_id", Value: 1}}
+	}
+
+	for _, field := range sort {
+		if field.Key == "_id" {
+			return sort
+		}
+	}
+
+	withID := make(bson.D, 0, len(sort)+1)
+	withID = append(withID, sort...)
+	withID = append(withID, bson.E{Key: "_id", Value: 1})
+	return withID
+}
+
+// sortDescending reports whether an index's declared direction (1, -1, or
+// their driver-typed equivalents) is descending.
+func sortDescending(direction any) bool {
+	switch v := direction.(type) {
+	case int:
+		return v < 0
+	case int32:
+		return v < 0
+	case int64:
+		return v < 0
+	default:
+		return false
+	}
+}
+
+// encodeCursor captures the value of every sort field on item, in sort
+// order, as an opaque base64 token that decodeCursorFilter can later turn
+// back into a seek filter.
+func encodeCursor(item any, sort bson.D) (string, error) {
+	raw, err := bson.Marshal(item)
+	if err != nil {
+		return "", err
+	}
+
+	doc := bson.Raw(raw)
+	values := make(bson.D, 0, len(sort))
+	for _, field := range sort {
+		value, err := doc.LookupErr(field.Key)
+		if err != nil {
+			return "", fmt.Errorf("sort field %q not present on result: %w", field.Key, err)
+		}
+		values = append(values, bson.E{Key: field.Key, Value: value})
+	}
+
+	encoded, err := bson.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.URLEncoding.EncodeToString(encoded), nil
+}
+
+// decodeCursorFilter turns a cursor token produced by encodeCursor into an
+// $or range filter over sort, using the standard keyset "seek" pattern:
+// match on equality for every preceding field and a strict range comparison
+// on the current one, for each prefix of sort in turn.
+func decodeCursorFilter(cursorToken string, sort bson.D) (bson.M, error) {
+	raw, err := base64.URLEncoding.DecodeString(cursorToken)
+	if err != nil {
+		return nil, err
+	}
+
+	elements, err := bson.Raw(raw).Elements()
+	if err != nil {
+		return nil, err
+	}
+	if len(elements) != len(sort) {
+		return nil, fmt.Errorf("cursor has %d fields, expected %d for the current sort", len(elements), len(sort))
+	}
+
+	clauses := make(bson.A, 0, len(sort))
+	for i, field := range sort {
+		clause := bson.M{}
+		for j := 0; j < i; j++ {
+			clause[sort[j].Key] = elements[j].Value()
+		}
+
+		op := "$gt"
+		if sortDescending(field.Value) {
+			op = "$lt"
+		}
+		clause[field.Key] = bson.M{op: elements[i].Value()}
+
+		clauses = append(clauses, clause)
+	}
+
+	return bson.M{"$or": clauses}, nil
+}
+
+// mergeFilters combines a caller-supplied filter with the keyset seek
+// filter derived from a cursor.
+func mergeFilters(filter bson.M, seek bson.M) bson.M {
+	if len(filter) == 0 {
+		return seek
+	}
+	return bson.M{"$and": bson.A{filter, seek}}
+}
@@ -0,0 +1,138 @@
+package mongobase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func findPageResponse(docs ...bson.D) bson.D {
+	return bson.D{
+		{Key: "ok", Value: 1},
+		{Key: "cursor", Value: bson.D{
+			{Key: "id", Value: int64(0)},
+			{Key: "ns", Value: "testdb.testcol"},
+			{Key: "firstBatch", Value: bson.A{docs[0], docs[1]}},
+		}},
+	}
+}
+
+func TestMongoBaseRepository_FindPage_OffsetMode(t *testing.T) {
+	client, deployment := setupMockClient(t)
+	defer client.Disconnect(context.Background())
+
+	deployment.AddResponses(findPageResponse(
+		bson.D{{Key: "_id", Value: "1"}, {Key: "name", Value: "John"}, {Key: "age", Value: 30}},
+		bson.D{{Key: "_id", Value: "2"}, {Key: "name", Value: "Jane"}, {Key: "age", Value: 25}},
+	))
+
+	repo := New[TestEntity](client, "testdb", "testcol")
+	page, err := repo.FindPage(context.Background(), bson.M{}, PageOptions{Limit: 2, Skip: 4})
+
+	require.NoError(t, err)
+	assert.Len(t, page.Items, 2)
+	assert.NotEmpty(t, page.NextCursor)
+	assert.Nil(t, page.Total)
+}
+
+func TestMongoBaseRepository_FindPage_IncludeTotal(t *testing.T) {
+	client, deployment := setupMockClient(t)
+	defer client.Disconnect(context.Background())
+
+	deployment.AddResponses(
+		findPageResponse(
+			bson.D{{Key: "_id", Value: "1"}, {Key: "name", Value: "John"}, {Key: "age", Value: 30}},
+			bson.D{{Key: "_id", Value: "2"}, {Key: "name", Value: "Jane"}, {Key: "age", Value: 25}},
+		),
+		bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "cursor", Value: bson.D{
+				{Key: "id", Value: int64(0)},
+				{Key: "ns", Value: "testdb.testcol"},
+				{Key: "firstBatch", Value: bson.A{bson.D{{Key: "n", Value: int64(42)}}}},
+			}},
+		},
+	)
+
+	repo := New[TestEntity](client, "testdb", "testcol")
+	page, err := repo.FindPage(context.Background(), bson.M{}, PageOptions{Limit: 2, IncludeTotal: true})
+
+	require.NoError(t, err)
+	require.NotNil(t, page.Total)
+	assert.Equal(t, int64(42), *page.Total)
+}
+
+func TestMongoBaseRepository_Count(t *testing.T) {
+	client, deployment := setupMockClient(t)
+	defer client.Disconnect(context.Background())
+
+	deployment.AddResponses(bson.D{
+		{Key: "ok", Value: 1},
+		{Key: "cursor", Value: bson.D{
+			{Key: "id", Value: int64(0)},
+			{Key: "ns", Value: "testdb.testcol"},
+			{Key: "firstBatch", Value: bson.A{bson.D{{Key: "n", Value: int64(7)}}}},
+		}},
+	})
+
+	repo := New[TestEntity](client, "testdb", "testcol")
+	count, err := repo.Count(context.Background(), bson.M{"age": bson.M{"$gte": 18}})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), count)
+}
+
+func TestMongoBaseRepository_Exists(t *testing.T) {
+	client, deployment := setupMockClient(t)
+	defer client.Disconnect(context.Background())
+
+	deployment.AddResponses(bson.D{
+		{Key: "ok", Value: 1},
+		{Key: "cursor", Value: bson.D{
+			{Key: "id", Value: int64(0)},
+			{Key: "ns", Value: "testdb.testcol"},
+			{Key: "firstBatch", Value: bson.A{bson.D{{Key: "n", Value: int64(1)}}}},
+		}},
+	})
+
+	repo := New[TestEntity](client, "testdb", "testcol")
+	exists, err := repo.Exists(context.Background(), bson.M{"name": "John Doe"})
+
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	sort := withIDTiebreaker(bson.D{{Key: "age", Value: -1}})
+
+	item := TestEntity{ID: "test-id-1", Name: "John Doe", Age: 30}
+	token, err := encodeCursor(item, sort)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+
+	filter, err := decodeCursorFilter(token, sort)
+	require.NoError(t, err)
+
+	or, ok := filter["$or"].(bson.A)
+	require.True(t, ok)
+	assert.Len(t, or, 2)
+
+	first, ok := or[0].(bson.M)
+	require.True(t, ok)
+	ageClause, ok := first["age"].(bson.M)
+	require.True(t, ok)
+	assert.Contains(t, ageClause, "$lt")
+}
+
+func TestWithIDTiebreaker(t *testing.T) {
+	assert.Equal(t, bson.D{{Key: "_id", Value: 1}}, withIDTiebreaker(nil))
+
+	withExisting := withIDTiebreaker(bson.D{{Key: "age", Value: -1}})
+	assert.Equal(t, bson.D{{Key: "age", Value: -1}, {Key: "_id", Value: 1}}, withExisting)
+
+	alreadyPresent := withIDTiebreaker(bson.D{{Key: "_id", Value: -1}})
+	assert.Equal(t, bson.D{{Key: "_id", Value: -1}}, alreadyPresent)
+}
@@ -0,0 +1,112 @@
+package mongobase
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// TxRepository binds a MongoBaseRepository to a single session context, so
+// every operation runs inside the transaction that context carries, without
+// the caller having to thread ctx through each call. Construct one per
+// entity type from inside a mongoclient.MongoClient.WithTransaction callback
+// to get multi-document atomicity across several repositories.
+//
+// The underlying MongoBaseRepository is deliberately unexported rather than
+// embedded: its read and write operations are wrapped below to bind the
+// session context, so there's no way to accidentally call through to a
+// ctx-taking method and pass the outer, non-session context by mistake
+// (which would silently run outside the transaction instead of failing to
+// compile). EnsureIndexes and Watch are not wrapped.
+//
+//	client.WithTransaction(ctx, func(sessCtx context.Context) (any, error) {
+//		users := mongobase.NewTx(userRepository, sessCtx)
+//		orders := mongobase.NewTx(orderRepository, sessCtx)
+//		if err := users.UpdateOneById(userID, update); err != nil {
+//			return nil, err
+//		}
+//		return nil, orders.InsertOne(order)
+//	})
+type TxRepository[T any] struct {
+	repo *MongoBaseRepository[T]
+	ctx  context.Context
+}
+
+// NewTx binds repo to sessCtx, returning a TxRepository whose methods no
+// longer take a context parameter.
+func NewTx[T any](repo *MongoBaseRepository[T], sessCtx context.Context) *TxRepository[T] {
+	return &TxRepository[T]{
+		repo: repo,
+		ctx:  sessCtx,
+	}
+}
+
+// InsertOne inserts document using the bound session context.
+func (tx *TxRepository[T]) InsertOne(document T) error {
+	return tx.repo.InsertOne(tx.ctx, document)
+}
+
+// FindOneById finds a document by _id using the bound session context.
+func (tx *TxRepository[T]) FindOneById(id string) (T, error) {
+	return tx.repo.FindOneById(tx.ctx, id)
+}
+
+// FindAll finds all documents matching filter using the bound session context.
+func (tx *TxRepository[T]) FindAll(filter bson.M) ([]T, error) {
+	return tx.repo.FindAll(tx.ctx, filter)
+}
+
+// FindPage finds a page of documents matching filter using the bound
+// session context.
+func (tx *TxRepository[T]) FindPage(filter bson.M, opts PageOptions) (Page[T], error) {
+	return tx.repo.FindPage(tx.ctx, filter, opts)
+}
+
+// Count counts documents matching filter using the bound session context.
+func (tx *TxRepository[T]) Count(filter bson.M) (int64, error) {
+	return tx.repo.Count(tx.ctx, filter)
+}
+
+// Exists reports whether any document matches filter, using the bound
+// session context.
+func (tx *TxRepository[T]) Exists(filter bson.M) (bool, error) {
+	return tx.repo.Exists(tx.ctx, filter)
+}
+
+// UpdateOneById updates a document by _id using the bound session context.
+func (tx *TxRepository[T]) UpdateOneById(id string, update bson.M) error {
+	return tx.repo.UpdateOneById(tx.ctx, id, update)
+}
+
+// UpsertOneById replaces the document with the given _id with doc, inserting
+// it if it doesn't already exist, using the bound session context.
+func (tx *TxRepository[T]) UpsertOneById(id string, doc T) error {
+	return tx.repo.UpsertOneById(tx.ctx, id, doc)
+}
+
+// DeleteOneById deletes a document by _id using the bound session context.
+func (tx *TxRepository[T]) DeleteOneById(id string) error {
+	return tx.repo.DeleteOneById(tx.ctx, id)
+}
+
+// BulkWrite executes ops using the bound session context.
+func (tx *TxRepository[T]) BulkWrite(ops []BulkOp[T], opts BulkOptions) (BulkResult, error) {
+	return tx.repo.BulkWrite(tx.ctx, ops, opts)
+}
+
+// InsertMany inserts docs using the bound session context.
+func (tx *TxRepository[T]) InsertMany(docs []T, opts BulkOptions) (BulkResult, error) {
+	return tx.repo.InsertMany(tx.ctx, docs, opts)
+}
+
+// UpdateMany applies update to every document matching filter, using the
+// bound session context.
+func (tx *TxRepository[T]) UpdateMany(filter, update bson.M) (BulkResult, error) {
+	return tx.repo.UpdateMany(tx.ctx, filter, update)
+}
+
+// DeleteMany deletes every document matching filter, using the bound
+// session context.
+func (tx *TxRepository[T]) DeleteMany(filter bson.M) (BulkResult, error) {
+	return tx.repo.DeleteMany(tx.ctx, filter)
+}
@@ -0,0 +1,264 @@
+package mongobase
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// initialBackoff and maxBackoff bound the exponential backoff Watch uses
+// when it has to reopen a change stream after a network error.
+const (
+	initialBackoff = 500 * time.Millisecond
+	maxBackoff     = 30 * time.Second
+)
+
+// ChangeEvent wraps a single change stream event, with fullDocument decoded
+// into T.
+type ChangeEvent[T any] struct {
+	OperationType     string
+	DocumentKey       bson.Raw
+	FullDocument      T
+	UpdateDescription bson.Raw
+	ResumeToken       bson.Raw
+}
+
+// TokenStore persists the resume token of the last event a subscriber saw,
+// so a restarted process can continue watching from where it left off.
+type TokenStore interface {
+	SaveToken(ctx context.Context, subscriberName string, token bson.Raw) error
+	LoadToken(ctx context.Context, subscriberName string) (bson.Raw, error)
+}
+
+// WatchOptions configures Watch.
+type WatchOptions struct {
+	// StartAfter/ResumeAfter seed the change stream at a specific resume
+	// token. If both are empty and TokenStore is set, the last token saved
+	// under SubscriberName is used instead.
+	StartAfter  bson.Raw
+	ResumeAfter bson.Raw
+
+	StartAtOperationTime *bson.Timestamp
+
+	// TokenStore, when set, is used to persist the resume token after every
+	// delivered event and to resume from it on startup and reconnects.
+	TokenStore TokenStore
+	// SubscriberName identifies this watcher to TokenStore. Required when
+	// TokenStore is set.
+	SubscriberName string
+}
+
+// Watch opens a change stream over the collection and streams decoded
+// events on the returned channel. Events are delivered as ChangeEvent[T]
+// with fullDocument decoded into T; any stream-level error is sent on the
+// error channel. Both channels are closed once ctx is done or an
+// unrecoverable error occurs.
+//
+// The driver itself already transparently resumes the stream on resumable
+// server/network errors encountered once it's open (see
+// mongo.ChangeStream's own resume logic), so any error drainStream returns
+// is by definition non-resumable and is surfaced immediately rather than
+// retried. The one error Watch retries itself is a network error from the
+// initial repo.collection.Watch call used to (re)open the stream, via
+// exponential backoff; a non-network failure to open the stream (e.g. an
+// invalid pipeline) is surfaced immediately instead.
+func (repo *MongoBaseRepository[T]) Watch(ctx context.Context, pipeline mongo.Pipeline, opts WatchOptions) (<-chan ChangeEvent[T], <-chan error) {
+	events := make(chan ChangeEvent[T])
+	errs := make(chan error, 1)
+
+	go repo.watchLoop(ctx, pipeline, opts, events, errs)
+
+	return events, errs
+}
+
+func (repo *MongoBaseRepository[T]) watchLoop(ctx context.Context, pipeline mongo.Pipeline, opts WatchOptions, events chan<- ChangeEvent[T], errs chan<- error) {
+	defer close(events)
+	defer close(errs)
+
+	resumeToken, err := repo.initialResumeToken(ctx, opts)
+	if err != nil {
+		errs <- err
+		return
+	}
+
+	backoff := initialBackoff
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		stream, err := repo.collection.Watch(ctx, pipeline, changeStreamOptions(opts, resumeToken))
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if !mongo.IsNetworkError(err) {
+				errs <- err
+				return
+			}
+			if !waitBackoff(ctx, &backoff, maxBackoff) {
+				return
+			}
+			continue
+		}
+
+		streamErr := repo.drainStream(ctx, stream, opts, events, &resumeToken)
+		stream.Close(ctx)
+
+		if streamErr == nil {
+			// Stream ended because ctx was cancelled.
+			return
+		}
+
+		// The driver already resumes the stream itself on resumable
+		// errors, so anything reaching here is fatal (e.g. an invalid
+		// pipeline or a dropped collection) and shouldn't be retried.
+		errs <- streamErr
+		return
+	}
+}
+
+// drainStream forwards events from stream until it errors or ctx is done.
+// It returns nil when ctx is done (a clean shutdown) and the stream's error
+// otherwise, so the caller knows whether to surface an error.
+func (repo *MongoBaseRepository[T]) drainStream(ctx context.Context, stream *mongo.ChangeStream, opts WatchOptions, events chan<- ChangeEvent[T], resumeToken *bson.Raw) error {
+	for stream.Next(ctx) {
+		var raw struct {
+			OperationType     string   `bson:"operationType"`
+			DocumentKey       bson.Raw `bson:"documentKey"`
+			FullDocument      bson.Raw `bson:"fullDocument"`
+			UpdateDescription bson.Raw `bson:"updateDescription"`
+		}
+		if err := stream.Decode(&raw); err != nil {
+			return err
+		}
+
+		var fullDocument T
+		if len(raw.FullDocument) > 0 {
+			if err := bson.Unmarshal(raw.FullDocument, &fullDocument); err != nil {
+				return err
+			}
+		}
+
+		token := stream.ResumeToken()
+		*resumeToken = token
+
+		event := ChangeEvent[T]{
+			OperationType:     raw.OperationType,
+			DocumentKey:       raw.DocumentKey,
+			FullDocument:      fullDocument,
+			UpdateDescription: raw.UpdateDescription,
+			ResumeToken:       token,
+		}
+
+		select {
+		case events <- event:
+		case <-ctx.Done():
+			return nil
+		}
+
+		if opts.TokenStore != nil {
+			if err := opts.TokenStore.SaveToken(ctx, opts.SubscriberName, token); err != nil {
+				return err
+			}
+		}
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	return stream.Err()
+}
+
+func (repo *MongoBaseRepository[T]) initialResumeToken(ctx context.Context, opts WatchOptions) (bson.Raw, error) {
+	if len(opts.StartAfter) > 0 {
+		return opts.StartAfter, nil
+	}
+	if len(opts.ResumeAfter) > 0 {
+		return opts.ResumeAfter, nil
+	}
+	if opts.TokenStore == nil {
+		return nil, nil
+	}
+	return opts.TokenStore.LoadToken(ctx, opts.SubscriberName)
+}
+
+func changeStreamOptions(opts WatchOptions, resumeToken bson.Raw) *options.ChangeStreamOptionsBuilder {
+	csOpts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+
+	switch {
+	case len(resumeToken) > 0:
+		csOpts.SetResumeAfter(resumeToken)
+	case opts.StartAtOperationTime != nil:
+		csOpts.SetStartAtOperationTime(opts.StartAtOperationTime)
+	}
+
+	return csOpts
+}
+
+// waitBackoff sleeps for *backoff (doubling it afterwards, capped at cap)
+// or returns false immediately if ctx is done first.
+func waitBackoff(ctx context.Context, backoff *time.Duration, cap time.Duration) bool {
+	timer := time.NewTimer(*backoff)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+	}
+
+	*backoff *= 2
+	if *backoff > cap {
+		*backoff = cap
+	}
+
+	return true
+}
+
+// MongoTokenStore is a TokenStore backed by a MongoDB collection, keyed by
+// subscriber name.
+type MongoTokenStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoTokenStore creates a TokenStore that persists resume tokens in
+// the given collection.
+func NewMongoTokenStore(collection *mongo.Collection) *MongoTokenStore {
+	return &MongoTokenStore{collection: collection}
+}
+
+type tokenDocument struct {
+	ID        string    `bson:"_id"`
+	Token     bson.Raw  `bson:"token"`
+	UpdatedAt time.Time `bson:"updatedAt"`
+}
+
+// SaveToken upserts the latest resume token for subscriberName.
+func (s *MongoTokenStore) SaveToken(ctx context.Context, subscriberName string, token bson.Raw) error {
+	_, err := s.collection.UpdateOne(ctx,
+		bson.M{"_id": subscriberName},
+		bson.M{"$set": bson.M{"token": token, "updatedAt": time.Now()}},
+		options.UpdateOne().SetUpsert(true),
+	)
+	return err
+}
+
+// LoadToken returns the last resume token saved for subscriberName, or nil
+// if none has been saved yet.
+func (s *MongoTokenStore) LoadToken(ctx context.Context, subscriberName string) (bson.Raw, error) {
+	var doc tokenDocument
+	err := s.collection.FindOne(ctx, bson.M{"_id": subscriberName}).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return doc.Token, nil
+}
@@ -0,0 +1,106 @@
+package mongobase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// TestWatch_SurfacesFatalOpenError exercises watchLoop end-to-end: when
+// opening the change stream fails for a non-network reason (e.g. an invalid
+// pipeline), the error must be surfaced on the error channel immediately,
+// not retried with backoff.
+func TestWatch_SurfacesFatalOpenError(t *testing.T) {
+	client, deployment := setupMockClient(t)
+	defer client.Disconnect(context.Background())
+
+	deployment.AddResponses(bson.D{
+		{Key: "ok", Value: 0},
+		{Key: "errmsg", Value: "Unrecognized pipeline stage name: '$invalidStage'"},
+		{Key: "code", Value: 40324},
+	})
+
+	repo := New[TestEntity](client, "testdb", "testcol")
+
+	_, errs := repo.Watch(context.Background(), nil, WatchOptions{})
+
+	select {
+	case err := <-errs:
+		require.Error(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("expected a fatal error on the error channel, got none within 1s")
+	}
+}
+
+// TestWatch_SurfacesFatalStreamError exercises drainStream returning a
+// non-resumable error once the stream is open: it must be surfaced
+// immediately rather than reconnected.
+func TestWatch_SurfacesFatalStreamError(t *testing.T) {
+	client, deployment := setupMockClient(t)
+	defer client.Disconnect(context.Background())
+
+	deployment.AddResponses(
+		bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "cursor", Value: bson.D{
+				{Key: "id", Value: int64(123)},
+				{Key: "ns", Value: "testdb.testcol"},
+				{Key: "firstBatch", Value: bson.A{}},
+			}},
+		},
+		bson.D{
+			{Key: "ok", Value: 0},
+			{Key: "errmsg", Value: "the change stream resume token is invalid"},
+			{Key: "code", Value: 280}, // ChangeStreamFatalError
+		},
+	)
+
+	repo := New[TestEntity](client, "testdb", "testcol")
+
+	_, errs := repo.Watch(context.Background(), nil, WatchOptions{})
+
+	select {
+	case err := <-errs:
+		require.Error(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a fatal error on the error channel, got none within 2s")
+	}
+}
+
+func TestWatch_ClosesChannelsWhenContextCancelled(t *testing.T) {
+	client, deployment := setupMockClient(t)
+	defer client.Disconnect(context.Background())
+
+	deployment.AddResponses(bson.D{
+		{Key: "ok", Value: 1},
+		{Key: "cursor", Value: bson.D{
+			{Key: "id", Value: int64(0)},
+			{Key: "ns", Value: "testdb.testcol"},
+			{Key: "firstBatch", Value: bson.A{}},
+		}},
+	})
+
+	repo := New[TestEntity](client, "testdb", "testcol")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, errs := repo.Watch(ctx, nil, WatchOptions{})
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok)
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected events channel to close within 2s")
+	}
+
+	select {
+	case _, ok := <-errs:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("expected errs channel to close within 1s")
+	}
+}
@@ -0,0 +1,103 @@
+package mongobase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestWaitBackoff_DoublesAndCaps(t *testing.T) {
+	cap := 10 * time.Millisecond
+	backoff := 6 * time.Millisecond
+	ok := waitBackoff(context.Background(), &backoff, cap)
+
+	assert.True(t, ok)
+	assert.Equal(t, cap, backoff)
+}
+
+func TestWaitBackoff_ReturnsFalseWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	backoff := time.Millisecond
+	ok := waitBackoff(ctx, &backoff, maxBackoff)
+
+	assert.False(t, ok)
+}
+
+func TestInitialResumeToken_PrefersExplicitTokens(t *testing.T) {
+	repo := &MongoBaseRepository[TestEntity]{}
+
+	startAfter := bson.Raw{0x01}
+	token, err := repo.initialResumeToken(context.Background(), WatchOptions{StartAfter: startAfter})
+	require.NoError(t, err)
+	assert.Equal(t, startAfter, token)
+
+	resumeAfter := bson.Raw{0x02}
+	token, err = repo.initialResumeToken(context.Background(), WatchOptions{ResumeAfter: resumeAfter})
+	require.NoError(t, err)
+	assert.Equal(t, resumeAfter, token)
+
+	token, err = repo.initialResumeToken(context.Background(), WatchOptions{})
+	require.NoError(t, err)
+	assert.Nil(t, token)
+}
+
+type fakeTokenStore struct {
+	saved map[string]bson.Raw
+}
+
+func (f *fakeTokenStore) SaveToken(ctx context.Context, subscriberName string, token bson.Raw) error {
+	f.saved[subscriberName] = token
+	return nil
+}
+
+func (f *fakeTokenStore) LoadToken(ctx context.Context, subscriberName string) (bson.Raw, error) {
+	return f.saved[subscriberName], nil
+}
+
+func TestInitialResumeToken_FallsBackToTokenStore(t *testing.T) {
+	repo := &MongoBaseRepository[TestEntity]{}
+	store := &fakeTokenStore{saved: map[string]bson.Raw{"sub": {0x03}}}
+
+	token, err := repo.initialResumeToken(context.Background(), WatchOptions{TokenStore: store, SubscriberName: "sub"})
+
+	require.NoError(t, err)
+	assert.Equal(t, bson.Raw{0x03}, token)
+}
+
+func TestMongoTokenStore_SaveAndLoad(t *testing.T) {
+	client, deployment := setupMockClient(t)
+	defer client.Disconnect(context.Background())
+
+	deployment.AddResponses(
+		bson.D{{Key: "ok", Value: 1}, {Key: "acknowledged", Value: true}, {Key: "matchedCount", Value: 0}, {Key: "upsertedId", Value: "sub"}},
+		bson.D{
+			{Key: "ok", Value: 1},
+			{Key: "cursor", Value: bson.D{
+				{Key: "id", Value: int64(0)},
+				{Key: "ns", Value: "testdb.tokens"},
+				{Key: "firstBatch", Value: bson.A{
+					bson.D{{Key: "_id", Value: "sub"}, {Key: "token", Value: bson.D{{Key: "_data", Value: "resume-token"}}}, {Key: "updatedAt", Value: time.Now()}},
+				}},
+			}},
+		},
+	)
+
+	collection := client.Database("testdb").Collection("tokens")
+	store := NewMongoTokenStore(collection)
+
+	token, err := bson.Marshal(bson.M{"_data": "resume-token"})
+	require.NoError(t, err)
+
+	err = store.SaveToken(context.Background(), "sub", token)
+	require.NoError(t, err)
+
+	loaded, err := store.LoadToken(context.Background(), "sub")
+	require.NoError(t, err)
+	assert.NotEmpty(t, loaded)
+}
@@ -0,0 +1,156 @@
+package mongoclient
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// DefaultTransactionDeadline is the default time budget WithTransaction
+// retries a transaction for, matching the MongoDB driver's own
+// Session.WithTransaction convention.
+const DefaultTransactionDeadline = 120 * time.Second
+
+const (
+	transientTransactionErrorLabel      = "TransientTransactionError"
+	unknownTransactionCommitResultLabel = "UnknownTransactionCommitResult"
+)
+
+// retryInitialBackoff and retryMaxBackoff bound the capped exponential
+// backoff applied between retry attempts in WithTransactionDeadline and
+// commitWithRetry, so a burst of transient errors (e.g. a replica-set
+// election) doesn't turn into a tight retry loop hammering the server.
+const (
+	retryInitialBackoff = 5 * time.Millisecond
+	retryMaxBackoff     = 250 * time.Millisecond
+)
+
+// WithTransaction runs fn inside a multi-document transaction, retrying the
+// whole transaction when it fails with a TransientTransactionError and
+// retrying just the commit when it fails with an
+// UnknownTransactionCommitResult, for up to DefaultTransactionDeadline. Use
+// WithTransactionDeadline to configure a different retry budget.
+//
+// fn receives a context carrying the session; it must be passed as the ctx
+// argument to every repository/collection call that should participate in
+// the transaction, and fn must be idempotent since it may run more than
+// once.
+//
+// If the deployment is a standalone node (no replica set, so transactions
+// aren't supported), WithTransaction falls back to running fn once, outside
+// of a transaction.
+func (mc *MongoClient) WithTransaction(
+	ctx context.Context,
+	fn func(sessCtx context.Context) (any, error),
+	opts ...options.Lister[options.TransactionOptions],
+) (any, error) {
+	return mc.WithTransactionDeadline(ctx, DefaultTransactionDeadline, fn, opts...)
+}
+
+// WithTransactionDeadline behaves like WithTransaction but retries for up to
+// deadline instead of DefaultTransactionDeadline.
+func (mc *MongoClient) WithTransactionDeadline(
+	ctx context.Context,
+	deadline time.Duration,
+	fn func(sessCtx context.Context) (any, error),
+	opts ...options.Lister[options.TransactionOptions],
+) (any, error) {
+	session, err := mc.Client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	deadlineAt := time.Now().Add(deadline)
+	backoff := retryInitialBackoff
+
+	for {
+		if err := session.StartTransaction(opts...); err != nil {
+			if isTransactionsUnsupported(err) {
+				return fn(mongo.NewSessionContext(ctx, session))
+			}
+			return nil, err
+		}
+
+		res, err := fn(mongo.NewSessionContext(ctx, session))
+		if err != nil {
+			_ = session.AbortTransaction(ctx)
+
+			// A standalone node rejects the first operation inside the
+			// transaction, not StartTransaction itself.
+			if isTransactionsUnsupported(err) {
+				return fn(ctx)
+			}
+			if time.Now().After(deadlineAt) || !hasErrorLabel(err, transientTransactionErrorLabel) {
+				return res, err
+			}
+			waitRetryBackoff(ctx, &backoff)
+			continue
+		}
+
+		commitErr := commitWithRetry(ctx, session, deadlineAt)
+		if commitErr == nil {
+			return res, nil
+		}
+		if time.Now().After(deadlineAt) || !hasErrorLabel(commitErr, transientTransactionErrorLabel) {
+			return res, commitErr
+		}
+		waitRetryBackoff(ctx, &backoff)
+	}
+}
+
+// commitWithRetry commits the active transaction, retrying only the commit
+// while the server reports an ambiguous (UnknownTransactionCommitResult)
+// outcome, until deadlineAt passes. Retries are spaced out with a capped
+// exponential backoff and jitter, same as the outer transaction retry loop.
+func commitWithRetry(ctx context.Context, session *mongo.Session, deadlineAt time.Time) error {
+	backoff := retryInitialBackoff
+	for {
+		err := session.CommitTransaction(ctx)
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadlineAt) || !hasErrorLabel(err, unknownTransactionCommitResultLabel) {
+			return err
+		}
+		waitRetryBackoff(ctx, &backoff)
+	}
+}
+
+// waitRetryBackoff sleeps for a jittered duration derived from *backoff
+// (doubling it afterwards, capped at retryMaxBackoff), returning early if
+// ctx is done first.
+func waitRetryBackoff(ctx context.Context, backoff *time.Duration) {
+	jittered := time.Duration(float64(*backoff) * (0.5 + rand.Float64()))
+
+	timer := time.NewTimer(jittered)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+
+	*backoff *= 2
+	if *backoff > retryMaxBackoff {
+		*backoff = retryMaxBackoff
+	}
+}
+
+// isTransactionsUnsupported reports whether err indicates the deployment is
+// a standalone node, which doesn't support transactions.
+func isTransactionsUnsupported(err error) bool {
+	var cmdErr mongo.CommandError
+	// Code 20 is IllegalOperation, raised for "Transaction numbers are only
+	// allowed on a replica set member or mongos".
+	return errors.As(err, &cmdErr) && cmdErr.Code == 20
+}
+
+func hasErrorLabel(err error, label string) bool {
+	var labeled mongo.LabeledError
+	return errors.As(err, &labeled) && labeled.HasErrorLabel(label)
+}
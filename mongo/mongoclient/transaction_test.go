@@ -0,0 +1,92 @@
+package mongoclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/x/mongo/driver/drivertest"
+)
+
+func setupMockClient(t *testing.T, responses ...bson.D) (*MongoClient, *drivertest.MockDeployment) {
+	deployment := drivertest.NewMockDeployment(responses...)
+
+	opts := options.Client()
+	opts.Deployment = deployment
+	client, err := mongo.Connect(opts)
+	require.NoError(t, err)
+
+	return &MongoClient{Client: client}, deployment
+}
+
+type labeledError struct {
+	label string
+}
+
+func (e labeledError) Error() string                   { return "transient error" }
+func (e labeledError) HasErrorLabel(label string) bool { return label == e.label }
+
+func TestHasErrorLabel(t *testing.T) {
+	withLabel := mongo.CommandError{Name: "err", Labels: []string{transientTransactionErrorLabel}}
+	withoutLabel := mongo.CommandError{Name: "err"}
+
+	assert.True(t, hasErrorLabel(withLabel, transientTransactionErrorLabel))
+	assert.False(t, hasErrorLabel(withoutLabel, transientTransactionErrorLabel))
+	assert.False(t, hasErrorLabel(assert.AnError, transientTransactionErrorLabel))
+}
+
+func TestIsTransactionsUnsupported(t *testing.T) {
+	standalone := mongo.CommandError{Code: 20, Message: "Transaction numbers are only allowed on a replica set member or mongos"}
+	other := mongo.CommandError{Code: 11000, Message: "duplicate key"}
+
+	assert.True(t, isTransactionsUnsupported(standalone))
+	assert.False(t, isTransactionsUnsupported(other))
+	assert.False(t, isTransactionsUnsupported(assert.AnError))
+}
+
+// TestWithTransactionDeadline_RetriesTransientErrorsUntilDeadline drives the
+// actual retry loop: fn always fails with a TransientTransactionError, so
+// WithTransactionDeadline must keep retrying (not give up after the first
+// attempt) until the deadline passes, then return that error.
+func TestWithTransactionDeadline_RetriesTransientErrorsUntilDeadline(t *testing.T) {
+	client, _ := setupMockClient(t)
+	defer client.Disconnect(context.Background())
+
+	var attempts int
+	transientErr := labeledError{label: transientTransactionErrorLabel}
+
+	start := time.Now()
+	_, err := client.WithTransactionDeadline(context.Background(), 30*time.Millisecond, func(sessCtx context.Context) (any, error) {
+		attempts++
+		return nil, transientErr
+	})
+	elapsed := time.Since(start)
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, transientErr)
+	assert.Greater(t, attempts, 1, "expected more than one attempt before the deadline was reached")
+	assert.Less(t, elapsed, 2*time.Second, "retry loop should stop shortly after the deadline, not hang")
+}
+
+// TestWithTransactionDeadline_ReturnsImmediatelyOnNonTransientError ensures a
+// non-retryable error from fn short-circuits the retry loop on the first
+// attempt instead of retrying until the deadline.
+func TestWithTransactionDeadline_ReturnsImmediatelyOnNonTransientError(t *testing.T) {
+	client, _ := setupMockClient(t)
+	defer client.Disconnect(context.Background())
+
+	var attempts int
+	_, err := client.WithTransactionDeadline(context.Background(), time.Minute, func(sessCtx context.Context) (any, error) {
+		attempts++
+		return nil, assert.AnError
+	})
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, 1, attempts)
+}
@@ -0,0 +1,123 @@
+package mongomigrate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// fileNamePattern matches "NNN_description.up.json" / "NNN_description.down.json".
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.json$`)
+
+// LoadMigrationsFromDir builds a Migration slice from a directory of
+// "NNN_name.up.json" / "NNN_name.down.json" file pairs, each containing a
+// JSON array of MongoDB commands run in order via db.RunCommand.
+//
+// A version without a down file is valid; Down on it returns an error only
+// if invoked.
+func LoadMigrationsFromDir(dir string) ([]Migration, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	type pair struct {
+		version     uint
+		description string
+		upFile      string
+		downFile    string
+	}
+	byVersion := map[uint]*pair{}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("mongomigrate: invalid version in file name %q: %w", entry.Name(), err)
+		}
+
+		p, ok := byVersion[uint(version)]
+		if !ok {
+			p = &pair{version: uint(version), description: match[2]}
+			byVersion[uint(version)] = p
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		if match[3] == "up" {
+			p.upFile = path
+		} else {
+			p.downFile = path
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, p := range byVersion {
+		if p.upFile == "" {
+			return nil, fmt.Errorf("mongomigrate: migration %d (%s) has no .up.json file", p.version, p.description)
+		}
+
+		migrations = append(migrations, Migration{
+			Version:     p.version,
+			Description: p.description,
+			Up:          runCommandFile(p.upFile),
+			Down:        runCommandFile(p.downFile),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// runCommandFile returns a migration step that reads a JSON array of Mongo
+// commands from path and runs them in order via db.RunCommand. An empty
+// path (no file present) returns a function that errors if ever invoked,
+// so Down on a migration without a .down.json file fails loudly instead of
+// silently doing nothing.
+func runCommandFile(path string) func(ctx context.Context, db *mongo.Database) error {
+	if path == "" {
+		return func(ctx context.Context, db *mongo.Database) error {
+			return fmt.Errorf("mongomigrate: no command file registered for this direction")
+		}
+	}
+
+	return func(ctx context.Context, db *mongo.Database) error {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		// Commands are decoded as bson.D to keep their field order: the
+		// command name must be the first field sent to RunCommand.
+		var file struct {
+			Commands []bson.D `bson:"commands"`
+		}
+		wrapped := append(append([]byte(`{"commands":`), raw...), '}')
+		if err := bson.UnmarshalExtJSON(wrapped, false, &file); err != nil {
+			return fmt.Errorf("mongomigrate: parsing %s: %w", path, err)
+		}
+
+		for _, command := range file.Commands {
+			if err := db.RunCommand(ctx, command).Err(); err != nil {
+				return fmt.Errorf("mongomigrate: running command from %s: %w", path, err)
+			}
+		}
+
+		return nil
+	}
+}
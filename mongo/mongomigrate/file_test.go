@@ -0,0 +1,41 @@
+package mongomigrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrationsFromDir(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, dir, "001_create_users.up.json", `[{"create": "users"}]`)
+	writeFile(t, dir, "001_create_users.down.json", `[{"drop": "users"}]`)
+	writeFile(t, dir, "002_add_index.up.json", `[{"createIndexes": "users", "indexes": []}]`)
+	writeFile(t, dir, "ignored.txt", `not a migration`)
+
+	migrations, err := LoadMigrationsFromDir(dir)
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+
+	assert.Equal(t, uint(1), migrations[0].Version)
+	assert.Equal(t, "create_users", migrations[0].Description)
+	assert.Equal(t, uint(2), migrations[1].Version)
+	assert.Equal(t, "add_index", migrations[1].Description)
+}
+
+func TestLoadMigrationsFromDir_MissingUpFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "001_create_users.down.json", `[{"drop": "users"}]`)
+
+	_, err := LoadMigrationsFromDir(dir)
+	assert.Error(t, err)
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+}
@@ -0,0 +1,355 @@
+// Package mongomigrate runs ordered, versioned migrations against a MongoDB
+// database, modeled after golang-migrate's mongodb driver.
+package mongomigrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+var (
+	// ErrDirty is returned when the migrator is asked to run while the
+	// tracked state is dirty, i.e. a previous migration failed partway
+	// through in non-transactional mode and needs manual repair.
+	ErrDirty = errors.New("mongomigrate: database is in a dirty state, manual repair required")
+
+	// ErrLocked is returned when another process is already holding the
+	// advisory migration lock.
+	ErrLocked = errors.New("mongomigrate: migration lock is held by another process")
+
+	// ErrNoChange is returned by Up/Down/Steps when there is nothing to do.
+	ErrNoChange = errors.New("mongomigrate: no migrations to run")
+
+	// ErrDuplicateVersion is returned by New when two migrations share a version.
+	ErrDuplicateVersion = errors.New("mongomigrate: duplicate migration version")
+)
+
+// DefaultStateCollection is the collection used to track applied versions
+// and hold the advisory lock, unless overridden via WithStateCollection.
+const DefaultStateCollection = "schema_migrations"
+
+const stateDocID = "state"
+
+// Migration is a single versioned change to a database. Up applies the
+// change; Down reverts it. Both run inside a transaction when the
+// deployment supports one, and are expected to be idempotent so that a
+// best-effort retry after a dirty failure is safe.
+type Migration struct {
+	Version     uint
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+	Down        func(ctx context.Context, db *mongo.Database) error
+}
+
+// Migrator applies a registered set of Migration values against a database,
+// tracking progress in a state collection guarded by an advisory lock so
+// that concurrent processes cannot run migrations simultaneously.
+type Migrator struct {
+	db              *mongo.Database
+	migrations      []Migration
+	stateCollection string
+}
+
+// Option configures a Migrator returned by New.
+type Option func(*Migrator)
+
+// WithStateCollection overrides the collection used to track migration
+// state. Defaults to DefaultStateCollection.
+func WithStateCollection(name string) Option {
+	return func(m *Migrator) {
+		m.stateCollection = name
+	}
+}
+
+// New creates a Migrator for db with the given migrations registered.
+//
+// Returns an error if two migrations share the same Version.
+func New(db *mongo.Database, migrations []Migration, opts ...Option) (*Migrator, error) {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Version == sorted[i-1].Version {
+			return nil, fmt.Errorf("%w: %d", ErrDuplicateVersion, sorted[i].Version)
+		}
+	}
+
+	migrator := &Migrator{
+		db:              db,
+		migrations:      sorted,
+		stateCollection: DefaultStateCollection,
+	}
+	for _, opt := range opts {
+		opt(migrator)
+	}
+
+	return migrator, nil
+}
+
+type migrationState struct {
+	ID        string    `bson:"_id"`
+	Version   uint      `bson:"version"`
+	Dirty     bool      `bson:"dirty"`
+	Locked    bool      `bson:"locked"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+// Version reports the most recently applied migration version and whether
+// the database is in a dirty state. A version of 0 with dirty false means
+// no migration has ever been applied.
+func (m *Migrator) Version(ctx context.Context) (uint, bool, error) {
+	var state migrationState
+	err := m.collection().FindOne(ctx, bson.M{"_id": stateDocID}).Decode(&state)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	return state.Version, state.Dirty, nil
+}
+
+// Up applies all registered migrations with a version greater than the
+// current version, up to and including target. A target of 0 applies
+// every registered migration.
+func (m *Migrator) Up(ctx context.Context, target uint) error {
+	return m.migrate(ctx, target, true)
+}
+
+// Down reverts all applied migrations with a version greater than target,
+// in descending order. A target of 0 reverts every applied migration.
+func (m *Migrator) Down(ctx context.Context, target uint) error {
+	return m.migrate(ctx, target, false)
+}
+
+// Steps applies the next n migrations when n is positive, or reverts the
+// last -n applied migrations when n is negative. n == 0 is a no-op.
+func (m *Migrator) Steps(ctx context.Context, n int) error {
+	if n == 0 {
+		return nil
+	}
+
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	defer m.unlock(ctx)
+
+	current, dirty, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return ErrDirty
+	}
+
+	if n > 0 {
+		pending := m.pendingUp(current, 0)
+		if len(pending) == 0 {
+			return ErrNoChange
+		}
+		if n < len(pending) {
+			pending = pending[:n]
+		}
+		return m.applyUp(ctx, pending)
+	}
+
+	pending := m.pendingDown(current, 0)
+	steps := -n
+	if steps < len(pending) {
+		pending = pending[:steps]
+	}
+	if len(pending) == 0 {
+		return ErrNoChange
+	}
+	return m.applyDown(ctx, pending)
+}
+
+func (m *Migrator) migrate(ctx context.Context, target uint, up bool) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	defer m.unlock(ctx)
+
+	current, dirty, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return ErrDirty
+	}
+
+	if up {
+		pending := m.pendingUp(current, target)
+		if len(pending) == 0 {
+			return ErrNoChange
+		}
+		return m.applyUp(ctx, pending)
+	}
+
+	pending := m.pendingDown(current, target)
+	if len(pending) == 0 {
+		return ErrNoChange
+	}
+	return m.applyDown(ctx, pending)
+}
+
+// pendingUp returns migrations with version > current, up to and including
+// target (or all of them, when target is 0), in ascending order.
+func (m *Migrator) pendingUp(current, target uint) []Migration {
+	var pending []Migration
+	for _, mig := range m.migrations {
+		if mig.Version <= current {
+			continue
+		}
+		if target != 0 && mig.Version > target {
+			continue
+		}
+		pending = append(pending, mig)
+	}
+	return pending
+}
+
+// pendingDown returns applied migrations with version > target (or all
+// applied migrations, when target is 0), in descending order.
+func (m *Migrator) pendingDown(current, target uint) []Migration {
+	var pending []Migration
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		mig := m.migrations[i]
+		if mig.Version > current {
+			continue
+		}
+		if mig.Version <= target {
+			continue
+		}
+		pending = append(pending, mig)
+	}
+	return pending
+}
+
+func (m *Migrator) applyUp(ctx context.Context, pending []Migration) error {
+	for _, mig := range pending {
+		if err := m.run(ctx, mig, mig.Up, mig.Version); err != nil {
+			return fmt.Errorf("mongomigrate: up migration %d (%s): %w", mig.Version, mig.Description, err)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) applyDown(ctx context.Context, pending []Migration) error {
+	for _, mig := range pending {
+		previous := uint(0)
+		if idx := m.indexOf(mig.Version); idx > 0 {
+			previous = m.migrations[idx-1].Version
+		}
+		if err := m.run(ctx, mig, mig.Down, previous); err != nil {
+			return fmt.Errorf("mongomigrate: down migration %d (%s): %w", mig.Version, mig.Description, err)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) indexOf(version uint) int {
+	for i, mig := range m.migrations {
+		if mig.Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// run executes fn, preferring a transaction when the deployment supports
+// one, and records recordedVersion as the new state on success. On
+// failure in non-transactional mode, the state is marked dirty so the
+// next run refuses to proceed without manual repair.
+func (m *Migrator) run(ctx context.Context, mig Migration, fn func(context.Context, *mongo.Database) error, recordedVersion uint) error {
+	session, err := m.db.Client().StartSession()
+	if err == nil {
+		defer session.EndSession(ctx)
+
+		_, txErr := session.WithTransaction(ctx, func(sessCtx context.Context) (any, error) {
+			return nil, fn(sessCtx, m.db)
+		})
+		if txErr == nil {
+			return m.recordVersion(ctx, recordedVersion, false)
+		}
+		if !isTransactionsUnsupported(txErr) {
+			return txErr
+		}
+		// Fall through to best-effort non-transactional execution below.
+	}
+
+	if err := fn(ctx, m.db); err != nil {
+		_ = m.recordVersion(ctx, recordedVersion, true)
+		return err
+	}
+
+	return m.recordVersion(ctx, recordedVersion, false)
+}
+
+// isTransactionsUnsupported reports whether err indicates the deployment
+// is a standalone node, which does not support transactions.
+func isTransactionsUnsupported(err error) bool {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		// IllegalOperation raised for "Transaction numbers are only allowed on a replica set member or mongos".
+		return cmdErr.Code == 20
+	}
+	return false
+}
+
+func (m *Migrator) recordVersion(ctx context.Context, version uint, dirty bool) error {
+	_, err := m.collection().UpdateOne(ctx,
+		bson.M{"_id": stateDocID},
+		bson.M{"$set": bson.M{"version": version, "dirty": dirty, "applied_at": time.Now()}},
+		options.UpdateOne().SetUpsert(true),
+	)
+	return err
+}
+
+// lock acquires the advisory migration lock, seeding the state document on
+// first use. It returns ErrLocked if another process already holds it.
+func (m *Migrator) lock(ctx context.Context) error {
+	_, err := m.collection().UpdateOne(ctx,
+		bson.M{"_id": stateDocID},
+		bson.M{"$setOnInsert": bson.M{"version": uint(0), "dirty": false}},
+		options.UpdateOne().SetUpsert(true),
+	)
+	if err != nil {
+		return err
+	}
+
+	res, err := m.collection().UpdateOne(ctx,
+		bson.M{"_id": stateDocID, "locked": bson.M{"$ne": true}},
+		bson.M{"$set": bson.M{"locked": true}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.ModifiedCount == 0 && res.MatchedCount == 0 {
+		return ErrLocked
+	}
+
+	return nil
+}
+
+// unlock releases the advisory migration lock acquired by lock.
+func (m *Migrator) unlock(ctx context.Context) error {
+	_, err := m.collection().UpdateOne(ctx,
+		bson.M{"_id": stateDocID},
+		bson.M{"$set": bson.M{"locked": false}},
+	)
+	return err
+}
+
+func (m *Migrator) collection() *mongo.Collection {
+	return m.db.Collection(m.stateCollection)
+}
@@ -0,0 +1,96 @@
+package mongomigrate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/x/mongo/driver/drivertest"
+)
+
+func setupMockDB(t *testing.T, responses ...bson.D) (*mongo.Database, *drivertest.MockDeployment) {
+	deployment := drivertest.NewMockDeployment(responses...)
+
+	opts := options.Client()
+	opts.Deployment = deployment
+	client, err := mongo.Connect(opts)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Disconnect(context.Background()) })
+
+	return client.Database("testdb"), deployment
+}
+
+func ackUpdate(matched, modified int) bson.D {
+	return bson.D{
+		{Key: "ok", Value: 1},
+		{Key: "n", Value: matched},
+		{Key: "nModified", Value: modified},
+	}
+}
+
+func stateDoc(version uint, dirty bool) bson.D {
+	return bson.D{
+		{Key: "ok", Value: 1},
+		{Key: "cursor", Value: bson.D{
+			{Key: "id", Value: int64(0)},
+			{Key: "ns", Value: "testdb." + DefaultStateCollection},
+			{Key: "firstBatch", Value: bson.A{
+				bson.D{
+					{Key: "_id", Value: stateDocID},
+					{Key: "version", Value: version},
+					{Key: "dirty", Value: dirty},
+				},
+			}},
+		}},
+	}
+}
+
+// TestMigrator_Up_ErrLockedWhenAnotherProcessHoldsTheLock drives lock()
+// through the public Up API: the advisory-lock update matches no document
+// (because it's already held by another process), so Up must fail with
+// ErrLocked without running any migration.
+func TestMigrator_Up_ErrLockedWhenAnotherProcessHoldsTheLock(t *testing.T) {
+	db, deployment := setupMockDB(t,
+		ackUpdate(1, 0), // $setOnInsert seed, matches the doc that already exists
+		ackUpdate(0, 0), // locked: {$ne: true} matches nothing -- already locked
+	)
+	_ = deployment
+
+	var ran bool
+	migrator, err := New(db, []Migration{
+		{Version: 1, Up: func(context.Context, *mongo.Database) error { ran = true; return nil }, Down: noopStep},
+	})
+	require.NoError(t, err)
+
+	err = migrator.Up(context.Background(), 0)
+
+	assert.ErrorIs(t, err, ErrLocked)
+	assert.False(t, ran, "no migration should run while the lock is held by another process")
+}
+
+// TestMigrator_Up_ErrDirtyBlocksRun drives Up end to end: the lock is
+// acquired successfully, but the tracked state is dirty from a previous
+// failed run, so Up must refuse to proceed.
+func TestMigrator_Up_ErrDirtyBlocksRun(t *testing.T) {
+	db, _ := setupMockDB(t,
+		ackUpdate(1, 0), // $setOnInsert seed
+		ackUpdate(1, 1), // lock acquired
+		stateDoc(1, true),
+		ackUpdate(1, 1), // unlock
+	)
+
+	var ran bool
+	migrator, err := New(db, []Migration{
+		{Version: 2, Up: func(context.Context, *mongo.Database) error { ran = true; return nil }, Down: noopStep},
+	})
+	require.NoError(t, err)
+
+	err = migrator.Up(context.Background(), 0)
+
+	assert.ErrorIs(t, err, ErrDirty)
+	assert.False(t, ran, "no migration should run while the tracked state is dirty")
+}
@@ -0,0 +1,95 @@
+package mongomigrate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func noopStep(context.Context, *mongo.Database) error { return nil }
+
+func TestNew_SortsAndRejectsDuplicateVersions(t *testing.T) {
+	migrator, err := New(nil, []Migration{
+		{Version: 2, Up: noopStep, Down: noopStep},
+		{Version: 1, Up: noopStep, Down: noopStep},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, migrator.migrations, 2)
+	assert.Equal(t, uint(1), migrator.migrations[0].Version)
+	assert.Equal(t, uint(2), migrator.migrations[1].Version)
+
+	_, err = New(nil, []Migration{
+		{Version: 1, Up: noopStep, Down: noopStep},
+		{Version: 1, Up: noopStep, Down: noopStep},
+	})
+	assert.ErrorIs(t, err, ErrDuplicateVersion)
+}
+
+func TestMigrator_PendingUp(t *testing.T) {
+	migrator, err := New(nil, []Migration{
+		{Version: 1, Up: noopStep, Down: noopStep},
+		{Version: 2, Up: noopStep, Down: noopStep},
+		{Version: 3, Up: noopStep, Down: noopStep},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		current  uint
+		target   uint
+		expected []uint
+	}{
+		{name: "all pending, no target", current: 0, target: 0, expected: []uint{1, 2, 3}},
+		{name: "partial, with target", current: 0, target: 2, expected: []uint{1, 2}},
+		{name: "from the middle", current: 1, target: 0, expected: []uint{2, 3}},
+		{name: "already at latest", current: 3, target: 0, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pending := migrator.pendingUp(tt.current, tt.target)
+
+			var versions []uint
+			for _, m := range pending {
+				versions = append(versions, m.Version)
+			}
+			assert.Equal(t, tt.expected, versions)
+		})
+	}
+}
+
+func TestMigrator_PendingDown(t *testing.T) {
+	migrator, err := New(nil, []Migration{
+		{Version: 1, Up: noopStep, Down: noopStep},
+		{Version: 2, Up: noopStep, Down: noopStep},
+		{Version: 3, Up: noopStep, Down: noopStep},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		current  uint
+		target   uint
+		expected []uint
+	}{
+		{name: "revert all", current: 3, target: 0, expected: []uint{3, 2, 1}},
+		{name: "revert to target", current: 3, target: 1, expected: []uint{3, 2}},
+		{name: "nothing applied", current: 0, target: 0, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pending := migrator.pendingDown(tt.current, tt.target)
+
+			var versions []uint
+			for _, m := range pending {
+				versions = append(versions, m.Version)
+			}
+			assert.Equal(t, tt.expected, versions)
+		})
+	}
+}